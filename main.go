@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-git/go-git/v5"
 	"github.com/rancher/charts-build-scripts/pkg/charts"
+	"github.com/rancher/charts-build-scripts/pkg/diff"
 	"github.com/rancher/charts-build-scripts/pkg/filesystem"
 	"github.com/rancher/charts-build-scripts/pkg/helm"
 	"github.com/rancher/charts-build-scripts/pkg/images"
@@ -38,6 +39,8 @@ const (
 	DefaultPorcelainEnvironmentVariable = "PORCELAIN"
 	// DefaultCacheEnvironmentVariable is the default environment variable that indicates that a cache should be used on pulls to remotes
 	DefaultCacheEnvironmentVariable = "USE_CACHE"
+	// DefaultStrictPatchEnvironmentVariable is the default environment variable that indicates that patches must apply without offset or fuzz
+	DefaultStrictPatchEnvironmentVariable = "STRICT"
 )
 
 var (
@@ -64,6 +67,8 @@ var (
 	RemoteMode bool
 	// CacheMode indicates that caching should be used on all remotely pulled resources
 	CacheMode = false
+	// StrictPatchMode indicates that patches that only apply with an offset or fuzz should fail instead of logging a warning
+	StrictPatchMode = false
 )
 
 func main() {
@@ -116,6 +121,13 @@ func main() {
 		Destination: &CacheMode,
 		EnvVar:      DefaultCacheEnvironmentVariable,
 	}
+	strictPatchFlag := cli.BoolFlag{
+		Name:        "strict",
+		Usage:       "Fail if a patch only applies with an offset or fuzz instead of logging a warning",
+		Required:    false,
+		Destination: &StrictPatchMode,
+		EnvVar:      DefaultStrictPatchEnvironmentVariable,
+	}
 	app.Commands = []cli.Command{
 		{
 			Name:   "list",
@@ -127,8 +139,8 @@ func main() {
 			Name:   "prepare",
 			Usage:  "Pull in the chart specified from upstream to the charts directory and apply any patch files",
 			Action: prepareCharts,
-			Before: setupCache,
-			Flags:  []cli.Flag{packageFlag, cacheFlag},
+			Before: setupCacheAndStrictPatchMode,
+			Flags:  []cli.Flag{packageFlag, cacheFlag, strictPatchFlag},
 		},
 		{
 			Name:   "patch",
@@ -141,8 +153,8 @@ func main() {
 			Name:   "charts",
 			Usage:  "Create a local chart archive of your finalized chart for testing",
 			Action: generateCharts,
-			Before: setupCache,
-			Flags:  []cli.Flag{packageFlag, configFlag, cacheFlag},
+			Before: setupCacheAndStrictPatchMode,
+			Flags:  []cli.Flag{packageFlag, configFlag, cacheFlag, strictPatchFlag},
 		},
 		{
 			Name:   "regsync",
@@ -458,6 +470,11 @@ func setupCache(c *cli.Context) error {
 	return puller.InitRootCache(CacheMode, path.DefaultCachePath)
 }
 
+func setupCacheAndStrictPatchMode(c *cli.Context) error {
+	diff.InitStrictPatchMode(StrictPatchMode)
+	return setupCache(c)
+}
+
 func cleanCache(c *cli.Context) {
 	if err := puller.CleanRootCache(path.DefaultCachePath); err != nil {
 		logrus.Fatal(err)
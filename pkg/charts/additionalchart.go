@@ -144,9 +144,9 @@ func (c *AdditionalChart) Prepare(rootFs, pkgFs billy.Filesystem, mainChartUpstr
 			return fmt.Errorf("encountered error while trying to convert upstream at %s into a Helm chart: %s", c.WorkingDir, err)
 		}
 		var err error
-		upstreamChartVersion, err := helm.GetHelmMetadataVersion(pkgFs, c.WorkingDir)
+		upstreamChartVersion, err := getUpstreamChartVersion(pkgFs, c.WorkingDir)
 		if err != nil {
-			return fmt.Errorf("encountered error while parsing original chart's version in %s: %s", c.WorkingDir, err)
+			return err
 		}
 		c.upstreamChartVersion = &upstreamChartVersion
 	}
@@ -2,6 +2,7 @@ package charts
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/blang/semver"
 	"github.com/go-git/go-billy/v5"
@@ -57,9 +58,9 @@ func (c *Chart) Prepare(rootFs, pkgFs billy.Filesystem) error {
 		return fmt.Errorf("encountered error while trying to convert upstream at %s into a Helm chart: %s", c.WorkingDir, err)
 	}
 	var err error
-	upstreamChartVersion, err = helm.GetHelmMetadataVersion(pkgFs, c.WorkingDir)
+	upstreamChartVersion, err = getUpstreamChartVersion(pkgFs, c.WorkingDir)
 	if err != nil {
-		return fmt.Errorf("encountered error while parsing original chart's version in %s: %s", c.WorkingDir, err)
+		return err
 	}
 	if err := PrepareDependencies(rootFs, pkgFs, c.WorkingDir, c.GeneratedChangesRootDir(), c.IgnoreDependencies); err != nil {
 		return fmt.Errorf("encountered error while trying to prepare dependencies in %s: %s", c.WorkingDir, err)
@@ -122,3 +123,13 @@ func (c *Chart) OriginalDir() string {
 func (c *Chart) GeneratedChangesRootDir() string {
 	return path.GeneratedChangesDir
 }
+
+// getUpstreamChartVersion returns the version of the upstream chart pulled into workingDir without any leading v (e.g. v1.2.3 becomes 1.2.3)
+// Exported chart versions never have a leading v, so keeping it would make an unmodified upstream chart look forked and get a spurious +upv1.2.3
+func getUpstreamChartVersion(pkgFs billy.Filesystem, workingDir string) (string, error) {
+	upstreamChartVersion, err := helm.GetHelmMetadataVersion(pkgFs, workingDir)
+	if err != nil {
+		return "", fmt.Errorf("encountered error while parsing original chart's version in %s: %s", workingDir, err)
+	}
+	return strings.TrimPrefix(upstreamChartVersion, "v"), nil
+}
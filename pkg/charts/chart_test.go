@@ -0,0 +1,55 @@
+package charts
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rancher/charts-build-scripts/pkg/filesystem"
+	"github.com/rancher/charts-build-scripts/pkg/helm"
+)
+
+func TestGetUpstreamChartVersion(t *testing.T) {
+	testCases := []struct {
+		chartVersion string
+	}{
+		{chartVersion: "v1.2.3"},
+		{chartVersion: "1.2.3"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.chartVersion, func(t *testing.T) {
+			rootFs := filesystem.GetFilesystem(t.TempDir())
+			pkgFs := filesystem.GetFilesystem(t.TempDir())
+			if err := os.MkdirAll(filesystem.GetAbsPath(pkgFs, "charts"), os.ModePerm); err != nil {
+				t.Fatal(err)
+			}
+			chartYaml := fmt.Sprintf("apiVersion: v2\nname: test-chart\nversion: %s\n", tc.chartVersion)
+			if err := os.WriteFile(filesystem.GetAbsPath(pkgFs, filepath.Join("charts", "Chart.yaml")), []byte(chartYaml), 0644); err != nil {
+				t.Fatal(err)
+			}
+			upstreamChartVersion, err := getUpstreamChartVersion(pkgFs, "charts")
+			if err != nil {
+				t.Fatalf("unable to get upstream chart version: %s", err)
+			}
+			if upstreamChartVersion != "1.2.3" {
+				t.Errorf("expected upstream chart version 1.2.3, got %s", upstreamChartVersion)
+			}
+			// An unmodified upstream chart should be exported without any +up build metadata
+			if err := helm.ExportHelmChart(rootFs, pkgFs, "charts", nil, nil, upstreamChartVersion, false); err != nil {
+				t.Fatalf("unable to export chart: %s", err)
+			}
+			chartVersions, err := rootFs.ReadDir(filepath.Join("charts", "test-chart"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(chartVersions) != 1 || chartVersions[0].Name() != "1.2.3" {
+				var names []string
+				for _, chartVersion := range chartVersions {
+					names = append(names, chartVersion.Name())
+				}
+				t.Errorf("expected chart to be exported as version 1.2.3, got %v", names)
+			}
+		})
+	}
+}
@@ -2,6 +2,8 @@ package charts
 
 import (
 	"fmt"
+	neturl "net/url"
+	"os"
 	"path/filepath"
 	"strings"
 
@@ -118,12 +120,18 @@ func GetPackage(rootFs billy.Filesystem, name string) (*Package, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := checkWorkingDirWithinRepository(packageRoot, chart.WorkingDir); err != nil {
+		return nil, err
+	}
 	var additionalCharts []*AdditionalChart
 	for _, additionalChartOptions := range packageOpt.AdditionalChartOptions {
 		additionalChart, err := GetAdditionalChartFromOptions(additionalChartOptions)
 		if err != nil {
 			return nil, err
 		}
+		if err := checkWorkingDirWithinRepository(packageRoot, additionalChart.WorkingDir); err != nil {
+			return nil, err
+		}
 		additionalCharts = append(additionalCharts, &additionalChart)
 	}
 	p := Package{
@@ -141,6 +149,30 @@ func GetPackage(rootFs billy.Filesystem, name string) (*Package, error) {
 	return &p, nil
 }
 
+// checkWorkingDirWithinRepository ensures that a working directory, which is resolved relative to the package root, cannot be used to modify files outside of the repository
+func checkWorkingDirWithinRepository(packageRoot, workingDir string) error {
+	if filepath.IsAbs(workingDir) {
+		return fmt.Errorf("working directory %s must be a path relative to the package", workingDir)
+	}
+	repositoryWorkingDir := filepath.Join(packageRoot, workingDir)
+	if !isWithinDirectory(repositoryWorkingDir) {
+		return fmt.Errorf("working directory %s resolves to %s, which is outside of the repository", workingDir, repositoryWorkingDir)
+	}
+	if repositoryWorkingDir == ".git" || strings.HasPrefix(repositoryWorkingDir, ".git/") {
+		return fmt.Errorf("working directory %s resolves to %s, which is within the repository's Git directory", workingDir, repositoryWorkingDir)
+	}
+	return nil
+}
+
+// isWithinDirectory returns whether a relative path resolves to a subdirectory of the directory it is relative to
+func isWithinDirectory(path string) bool {
+	if filepath.IsAbs(path) {
+		return false
+	}
+	cleanPath := filepath.Clean(path)
+	return cleanPath != "." && cleanPath != ".." && !strings.HasPrefix(cleanPath, "../")
+}
+
 // GetChartFromOptions returns a Chart based on the options provided
 func GetChartFromOptions(opt options.ChartOptions) (Chart, error) {
 	upstream, err := GetUpstream(opt.UpstreamOptions)
@@ -204,9 +236,23 @@ func GetAdditionalChartFromOptions(opt options.AdditionalChartOptions) (Addition
 
 // GetUpstream returns the appropriate Upstream given the options provided
 func GetUpstream(opt options.UpstreamOptions) (puller.Puller, error) {
-	if opt.URL == "" {
+	url, err := expandUpstreamURL(opt.URL)
+	if err != nil {
+		return nil, err
+	}
+	if url == "" {
 		return nil, fmt.Errorf("URL is not defined")
 	}
+	if url != opt.URL && strings.HasSuffix(url, ".git") && getGitHost(url) != "github.com" {
+		// Git repositories are always cloned from Github, so a mirror would silently be ignored
+		return nil, fmt.Errorf("URL %s expands to %s, but Git repositories can only be pulled from github.com", opt.URL, url)
+	}
+	opt.URL = url
+	if opt.Subdirectory != nil && len(*opt.Subdirectory) > 0 {
+		if !isWithinDirectory(*opt.Subdirectory) {
+			return nil, fmt.Errorf("subdirectory %s must be a relative path within the upstream", *opt.Subdirectory)
+		}
+	}
 	if opt.URL == "local" {
 		upstream := Local{}
 		return upstream, nil
@@ -248,3 +294,28 @@ func GetUpstream(opt options.UpstreamOptions) (puller.Puller, error) {
 	}
 	return nil, fmt.Errorf("URL is invalid (must contain .git or .tgz)")
 }
+
+// expandUpstreamURL replaces any $VAR or ${VAR} references in an upstream URL with the values of the corresponding environment variables
+func expandUpstreamURL(url string) (string, error) {
+	var missingVars []string
+	expandedURL := os.Expand(url, func(key string) string {
+		value, ok := os.LookupEnv(key)
+		if !ok {
+			missingVars = append(missingVars, key)
+		}
+		return value
+	})
+	if len(missingVars) > 0 {
+		return "", fmt.Errorf("URL %s references environment variables that are not set: %s", url, strings.Join(missingVars, ", "))
+	}
+	return expandedURL, nil
+}
+
+// getGitHost returns the host of a Git URL, which can either be a URL or use the scp-like syntax (e.g. git@github.com:rancher/charts.git)
+func getGitHost(gitURL string) string {
+	if u, err := neturl.Parse(gitURL); err == nil && len(u.Host) > 0 {
+		return u.Hostname()
+	}
+	host := strings.SplitN(gitURL, ":", 2)[0]
+	return host[strings.LastIndex(host, "@")+1:]
+}
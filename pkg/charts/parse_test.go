@@ -0,0 +1,150 @@
+package charts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rancher/charts-build-scripts/pkg/filesystem"
+	"github.com/rancher/charts-build-scripts/pkg/options"
+)
+
+func TestCheckWorkingDirWithinRepository(t *testing.T) {
+	testCases := []struct {
+		workingDir  string
+		expectError bool
+	}{
+		{workingDir: "charts", expectError: false},
+		{workingDir: "charts/nested", expectError: false},
+		{workingDir: "./charts", expectError: false},
+		{workingDir: "../other-package/charts", expectError: false},
+		{workingDir: "../../assets", expectError: false},
+		{workingDir: "../..", expectError: true},
+		{workingDir: "../../..", expectError: true},
+		{workingDir: "../../../other-repository", expectError: true},
+		{workingDir: "charts/../../../..", expectError: true},
+		{workingDir: "../../.git", expectError: true},
+		{workingDir: "../../.git/hooks", expectError: true},
+		{workingDir: "/tmp/charts", expectError: true},
+	}
+	for _, tc := range testCases {
+		err := checkWorkingDirWithinRepository(filepath.Join("packages", "test"), tc.workingDir)
+		if tc.expectError && err == nil {
+			t.Errorf("expected working directory %q to be rejected", tc.workingDir)
+		}
+		if !tc.expectError && err != nil {
+			t.Errorf("expected working directory %q to be accepted, got: %s", tc.workingDir, err)
+		}
+	}
+}
+
+func TestGetPackageWorkingDir(t *testing.T) {
+	testCases := []struct {
+		packageYaml string
+		expectError bool
+	}{
+		{packageYaml: "url: local\nworkingDir: charts\n", expectError: false},
+		{packageYaml: "url: local\nworkingDir: ../..\n", expectError: true},
+		{packageYaml: "url: local\nadditionalCharts:\n- workingDir: charts-crd\n  upstreamOptions:\n    url: local\n", expectError: false},
+		{packageYaml: "url: local\nadditionalCharts:\n- workingDir: ../../..\n  upstreamOptions:\n    url: local\n", expectError: true},
+	}
+	for _, tc := range testCases {
+		rootFs := filesystem.GetFilesystem(t.TempDir())
+		packageYamlPath := filesystem.GetAbsPath(rootFs, filepath.Join("packages", "test", "package.yaml"))
+		if err := os.MkdirAll(filepath.Dir(packageYamlPath), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(packageYamlPath, []byte(tc.packageYaml), 0644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := GetPackage(rootFs, "test")
+		if tc.expectError && err == nil {
+			t.Errorf("expected package.yaml %q to be rejected", tc.packageYaml)
+		}
+		if !tc.expectError && err != nil {
+			t.Errorf("expected package.yaml %q to be accepted, got: %s", tc.packageYaml, err)
+		}
+	}
+}
+
+func TestExpandUpstreamURL(t *testing.T) {
+	t.Setenv("MIRROR", "https://mirror.example.com/charts")
+	testCases := []struct {
+		url         string
+		expectedURL string
+		expectError bool
+	}{
+		{url: "https://github.com/rancher/charts.git", expectedURL: "https://github.com/rancher/charts.git"},
+		{url: "$MIRROR/chart-1.0.0.tgz", expectedURL: "https://mirror.example.com/charts/chart-1.0.0.tgz"},
+		{url: "${MIRROR}/chart-1.0.0.tgz", expectedURL: "https://mirror.example.com/charts/chart-1.0.0.tgz"},
+		{url: "${CHARTS_BUILD_SCRIPTS_UNSET_MIRROR}/chart-1.0.0.tgz", expectError: true},
+	}
+	for _, tc := range testCases {
+		url, err := expandUpstreamURL(tc.url)
+		if tc.expectError {
+			if err == nil {
+				t.Errorf("expected expanding %q to fail, got %q", tc.url, url)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("expected expanding %q to succeed, got: %s", tc.url, err)
+			continue
+		}
+		if url != tc.expectedURL {
+			t.Errorf("expected %q to expand to %q, got %q", tc.url, tc.expectedURL, url)
+		}
+	}
+}
+
+func TestGetUpstreamInterpolatedURL(t *testing.T) {
+	t.Setenv("MIRROR", "https://mirror.example.com/charts")
+	t.Setenv("GITHUB", "https://github.com")
+	t.Setenv("EMPTY", "")
+	testCases := []struct {
+		url         string
+		expectError bool
+	}{
+		{url: "${MIRROR}/chart-1.0.0.tgz", expectError: false},
+		{url: "${GITHUB}/rancher/charts.git", expectError: false},
+		{url: "${MIRROR}/rancher/charts.git", expectError: true},
+		{url: "${EMPTY}", expectError: true},
+	}
+	for _, tc := range testCases {
+		_, err := GetUpstream(options.UpstreamOptions{URL: tc.url})
+		if tc.expectError && err == nil {
+			t.Errorf("expected URL %q to be rejected", tc.url)
+		}
+		if !tc.expectError && err != nil {
+			t.Errorf("expected URL %q to be accepted, got: %s", tc.url, err)
+		}
+	}
+}
+
+func TestGetUpstreamSubdirectory(t *testing.T) {
+	testCases := []struct {
+		subdirectory string
+		expectError  bool
+	}{
+		{subdirectory: "", expectError: false},
+		{subdirectory: "charts/rancher", expectError: false},
+		{subdirectory: "./charts", expectError: false},
+		{subdirectory: "/charts", expectError: true},
+		{subdirectory: "..", expectError: true},
+		{subdirectory: "../charts", expectError: true},
+		{subdirectory: "charts/../../charts", expectError: true},
+	}
+	for _, tc := range testCases {
+		subdirectory := tc.subdirectory
+		_, err := GetUpstream(options.UpstreamOptions{
+			URL:          "https://example.com/chart-1.0.0.tgz",
+			Subdirectory: &subdirectory,
+		})
+		if tc.expectError && err == nil {
+			t.Errorf("expected subdirectory %q to be rejected", tc.subdirectory)
+		}
+		if !tc.expectError && err != nil {
+			t.Errorf("expected subdirectory %q to be accepted, got: %s", tc.subdirectory, err)
+		}
+	}
+}
@@ -4,13 +4,23 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"os"
 	"os/exec"
+	"strings"
 
 	"github.com/go-git/go-billy/v5"
 	"github.com/rancher/charts-build-scripts/pkg/filesystem"
 	"github.com/sirupsen/logrus"
 )
 
+// strictPatchMode indicates that a patch that only applies with an offset or fuzz should be treated as an error instead of a warning
+var strictPatchMode = false
+
+// InitStrictPatchMode sets whether patches that only apply with an offset or fuzz should fail instead of logging a warning
+func InitStrictPatchMode(strict bool) {
+	strictPatchMode = strict
+}
+
 // GeneratePatch generates the patch between the files at srcPath and dstPath and outputs it to patchPath
 // It returns whether the patch was generated or any errors that were encountered
 func GeneratePatch(fs billy.Filesystem, patchPath, srcPath, dstPath string) (bool, error) {
@@ -68,14 +78,47 @@ func ApplyPatch(fs billy.Filesystem, patchPath, destDir string) error {
 
 	cmd := exec.Command(pathToPatchCmd, "-E", "-p1")
 	cmd.Dir = filesystem.GetAbsPath(fs, destDir)
+	// The output is parsed below, so it must not be translated
+	cmd.Env = append(os.Environ(), "LC_ALL=C")
 	cmd.Stdin = patchFile
 	cmd.Stdout = &buf
 
 	if err = cmd.Run(); err != nil {
 		logrus.Errorf("\n%s", &buf)
-		err = fmt.Errorf("unable to generate patch with error: %s", err)
+		return fmt.Errorf("unable to generate patch with error: %s", err)
+	}
+
+	// GNU patch still succeeds if a hunk only matches with an offset or fuzz, which indicates the patch is stale
+	inexactHunks := getInexactHunks(&buf)
+	if len(inexactHunks) == 0 {
+		return nil
+	}
+	if strictPatchMode {
+		return fmt.Errorf("patch %s did not apply cleanly and needs to be regenerated: %s", patchPath, strings.Join(inexactHunks, "; "))
+	}
+	logrus.Warnf("Patch %s applied with offset or fuzz and should be regenerated: %s", patchPath, strings.Join(inexactHunks, "; "))
+	return nil
+}
+
+// getInexactHunks returns the lines of GNU patch output that report a hunk applied with an offset or fuzz, prefixed by the file they apply to
+func getInexactHunks(in *bytes.Buffer) []string {
+	var inexactHunks []string
+	var patchedFile string
+	s := bufio.NewScanner(in)
+	for s.Scan() {
+		line := s.Text()
+		if strings.HasPrefix(line, "patching file ") {
+			patchedFile = strings.TrimPrefix(line, "patching file ")
+			continue
+		}
+		if !strings.HasPrefix(line, "Hunk #") {
+			continue
+		}
+		if strings.Contains(line, "offset") || strings.Contains(line, "fuzz") {
+			inexactHunks = append(inexactHunks, fmt.Sprintf("%s: %s", patchedFile, line))
+		}
 	}
-	return err
+	return inexactHunks
 }
 
 // removeTimestamps removes timestamps from a given patch file
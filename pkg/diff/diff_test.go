@@ -0,0 +1,82 @@
+package diff
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rancher/charts-build-scripts/pkg/filesystem"
+)
+
+func TestGetInexactHunks(t *testing.T) {
+	output := strings.Join([]string{
+		"patching file chart/values.yaml",
+		"Hunk #1 succeeded at 3 (offset 2 lines).",
+		"Hunk #2 succeeded at 10.",
+		"patching file chart/Chart.yaml",
+		"patching file chart/templates/deployment.yaml",
+		"Hunk #1 succeeded at 7 with fuzz 1.",
+		"",
+	}, "\n")
+	expected := []string{
+		"chart/values.yaml: Hunk #1 succeeded at 3 (offset 2 lines).",
+		"chart/templates/deployment.yaml: Hunk #1 succeeded at 7 with fuzz 1.",
+	}
+	inexactHunks := getInexactHunks(bytes.NewBufferString(output))
+	if !reflect.DeepEqual(inexactHunks, expected) {
+		t.Errorf("expected %q, got %q", expected, inexactHunks)
+	}
+}
+
+func TestApplyPatchWithOffset(t *testing.T) {
+	if _, err := exec.LookPath("patch"); err != nil {
+		t.Skip("GNU patch is not available")
+	}
+	// The patch expects "b" on line 2, but two lines were added above it since the patch was generated
+	patch := strings.Join([]string{
+		"--- a/file",
+		"+++ b/file",
+		"@@ -1,3 +1,3 @@",
+		" a",
+		"-b",
+		"+B",
+		" c",
+		"",
+	}, "\n")
+	testCases := []struct {
+		name        string
+		strict      bool
+		expectError bool
+	}{
+		{name: "warns by default", strict: false, expectError: false},
+		{name: "fails in strict mode", strict: true, expectError: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer InitStrictPatchMode(false)
+			InitStrictPatchMode(tc.strict)
+
+			fs := filesystem.GetFilesystem(t.TempDir())
+			if err := os.MkdirAll(filesystem.GetAbsPath(fs, "chart"), os.ModePerm); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filesystem.GetAbsPath(fs, filepath.Join("chart", "file")), []byte("x\ny\na\nb\nc\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filesystem.GetAbsPath(fs, "file.patch"), []byte(patch), 0644); err != nil {
+				t.Fatal(err)
+			}
+			err := ApplyPatch(fs, "file.patch", "chart")
+			if tc.expectError && err == nil {
+				t.Errorf("expected applying a patch with an offset to fail in strict mode")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("expected applying a patch with an offset to succeed, got: %s", err)
+			}
+		})
+	}
+}
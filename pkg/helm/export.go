@@ -5,6 +5,7 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/blang/semver"
 	"github.com/go-git/go-billy/v5"
@@ -36,7 +37,8 @@ func ExportHelmChart(rootFs, fs billy.Filesystem, helmChartPath string, packageV
 	if err := chart.Validate(); err != nil {
 		return fmt.Errorf("failed while trying to validate Helm chart: %s", err)
 	}
-	chartVersionSemver, err := semver.Make(chart.Metadata.Version)
+	// Some upstream charts report their version with a leading v (e.g. v1.2.3), which is not valid semver
+	chartVersionSemver, err := semver.Make(strings.TrimPrefix(chart.Metadata.Version, "v"))
 	if err != nil {
 		return fmt.Errorf("cannot parse original chart version %s as valid semver", chart.Metadata.Version)
 	}
@@ -0,0 +1,45 @@
+package helm
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rancher/charts-build-scripts/pkg/filesystem"
+)
+
+func TestExportHelmChartVersionPrefix(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		chartVersion         string
+		upstreamChartVersion string
+	}{
+		{name: "leading v", chartVersion: "v1.2.3", upstreamChartVersion: "1.2.3"},
+		{name: "no leading v", chartVersion: "1.2.3", upstreamChartVersion: "1.2.3"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rootFs := filesystem.GetFilesystem(t.TempDir())
+			chartDir := filesystem.GetAbsPath(rootFs, "chart")
+			if err := os.MkdirAll(chartDir, os.ModePerm); err != nil {
+				t.Fatal(err)
+			}
+			chartYaml := fmt.Sprintf("apiVersion: v2\nname: test-chart\nversion: %s\n", tc.chartVersion)
+			if err := os.WriteFile(filepath.Join(chartDir, "Chart.yaml"), []byte(chartYaml), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := ExportHelmChart(rootFs, rootFs, "chart", nil, nil, tc.upstreamChartVersion, false); err != nil {
+				t.Fatalf("unable to export chart with version %s: %s", tc.chartVersion, err)
+			}
+			tgzPath := filepath.Join("assets", "test-chart", "test-chart-1.2.3.tgz")
+			exists, err := filesystem.PathExists(rootFs, tgzPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !exists {
+				t.Errorf("expected %s to be generated for chart version %s", tgzPath, tc.chartVersion)
+			}
+		})
+	}
+}
@@ -1,8 +1,8 @@
 package options
 
 import (
+	"fmt"
 	"io/ioutil"
-	"os"
 
 	"github.com/hashicorp/go-version"
 	"golang.org/x/exp/slices"
@@ -82,7 +82,10 @@ func LoadReleaseOptionsFromFile(fs billy.Filesystem, path string) (ReleaseOption
 	if err != nil {
 		return releaseOptions, err
 	}
-	return releaseOptions, yaml.Unmarshal(releaseOptionsBytes, &releaseOptions)
+	if err := yaml.Unmarshal(releaseOptionsBytes, &releaseOptions); err != nil {
+		return nil, fmt.Errorf("file may be corrupted or only partially written: %s", err)
+	}
+	return releaseOptions, nil
 }
 
 // SortBySemver sorts the version strings in release options according to semver constraints
@@ -109,6 +112,7 @@ func CompareVersions(a string, b string) bool {
 }
 
 // WriteToFile marshals the struct to yaml and writes it into the path specified
+// The contents are written to a temporary file that is then renamed to path, so an interrupted write never leaves a partial file behind
 func (r ReleaseOptions) WriteToFile(fs billy.Filesystem, path string) error {
 	r.SortBySemver()
 
@@ -116,20 +120,23 @@ func (r ReleaseOptions) WriteToFile(fs billy.Filesystem, path string) error {
 	if err != nil {
 		return err
 	}
-	exists, err := filesystem.PathExists(fs, path)
+	tempPath := path + ".temp"
+	file, err := filesystem.CreateFileAndDirs(fs, tempPath)
 	if err != nil {
 		return err
 	}
-	var file billy.File
-	if !exists {
-		file, err = filesystem.CreateFileAndDirs(fs, path)
-	} else {
-		file, err = fs.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, os.ModePerm)
+	if _, err := file.Write(releaseOptionsBytes); err != nil {
+		file.Close()
+		fs.Remove(tempPath)
+		return err
 	}
-	if err != nil {
+	if err := file.Close(); err != nil {
+		fs.Remove(tempPath)
 		return err
 	}
-	defer file.Close()
-	_, err = file.Write(releaseOptionsBytes)
-	return err
+	if err := fs.Rename(tempPath, path); err != nil {
+		fs.Remove(tempPath)
+		return fmt.Errorf("unable to replace %s with %s: %s", path, tempPath, err)
+	}
+	return nil
 }
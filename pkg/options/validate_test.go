@@ -0,0 +1,62 @@
+package options
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/rancher/charts-build-scripts/pkg/filesystem"
+)
+
+func TestReleaseOptionsInterruptedWrite(t *testing.T) {
+	fs := filesystem.GetFilesystem(t.TempDir())
+	releaseOptions := ReleaseOptions{"chart": []string{"1.0.0"}}
+	if err := releaseOptions.WriteToFile(fs, "release.yaml"); err != nil {
+		t.Fatalf("unable to write release.yaml: %s", err)
+	}
+	// Simulate a write that was interrupted before the temporary file replaced release.yaml
+	if err := os.WriteFile(filesystem.GetAbsPath(fs, "release.yaml.temp"), []byte("chart:\n- 1.0.0\n- 2.0"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	loadedReleaseOptions, err := LoadReleaseOptionsFromFile(fs, "release.yaml")
+	if err != nil {
+		t.Fatalf("expected release.yaml to be unaffected by an interrupted write, got: %s", err)
+	}
+	if !reflect.DeepEqual(loadedReleaseOptions, releaseOptions) {
+		t.Errorf("expected %v, got %v", releaseOptions, loadedReleaseOptions)
+	}
+	// The next write should replace the leftover temporary file
+	releaseOptions["chart"] = append(releaseOptions["chart"], "2.0.0")
+	if err := releaseOptions.WriteToFile(fs, "release.yaml"); err != nil {
+		t.Fatalf("unable to write release.yaml: %s", err)
+	}
+	exists, err := filesystem.PathExists(fs, "release.yaml.temp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exists {
+		t.Errorf("expected release.yaml.temp to be removed after a successful write")
+	}
+	loadedReleaseOptions, err = LoadReleaseOptionsFromFile(fs, "release.yaml")
+	if err != nil {
+		t.Fatalf("unable to load release.yaml: %s", err)
+	}
+	if !reflect.DeepEqual(loadedReleaseOptions, releaseOptions) {
+		t.Errorf("expected %v, got %v", releaseOptions, loadedReleaseOptions)
+	}
+}
+
+func TestLoadReleaseOptionsFromCorruptFile(t *testing.T) {
+	fs := filesystem.GetFilesystem(t.TempDir())
+	// A release.yaml that was truncated in the middle of a quoted version
+	if err := os.WriteFile(filesystem.GetAbsPath(fs, "release.yaml"), []byte("chart:\n- \"1.0.0\"\n- \"2.0"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	releaseOptions, err := LoadReleaseOptionsFromFile(fs, "release.yaml")
+	if err == nil {
+		t.Errorf("expected loading a corrupt release.yaml to fail")
+	}
+	if releaseOptions != nil {
+		t.Errorf("expected no release options to be returned from a corrupt release.yaml, got %v", releaseOptions)
+	}
+}
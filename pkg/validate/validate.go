@@ -47,7 +47,7 @@ func (r CompareGeneratedAssetsResponse) LogDiscrepancies() {
 func (r CompareGeneratedAssetsResponse) DumpReleaseYaml(repoFs billy.Filesystem) error {
 	releaseYaml, err := options.LoadReleaseOptionsFromFile(repoFs, ReleaseYamlFileName)
 	if err != nil {
-		return err
+		return fmt.Errorf("unable to load %s: %s", ReleaseYamlFileName, err)
 	}
 
 	if releaseYaml == nil {